@@ -0,0 +1,53 @@
+package configs
+
+import (
+	"github.com/hashicorp/hcl2/hcl"
+)
+
+// CheckRule represents a configuration-defined validation rule, such as a
+// precondition or postcondition block attached to a resource's lifecycle
+// block or to an output value.
+type CheckRule struct {
+	// Condition is an expression that must evaluate to true in order for
+	// the check to pass.
+	Condition hcl.Expression
+
+	// ErrorMessage is an expression that evaluates to the message to
+	// include in the diagnostic Terraform raises when Condition evaluates
+	// to false. It should be one or more full sentences.
+	ErrorMessage hcl.Expression
+
+	DeclRange hcl.Range
+}
+
+var checkRuleBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name:     "condition",
+			Required: true,
+		},
+		{
+			Name:     "error_message",
+			Required: true,
+		},
+	},
+}
+
+func decodeCheckRuleBlock(block *hcl.Block) (*CheckRule, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	cr := &CheckRule{
+		DeclRange: block.DefRange,
+	}
+
+	content, moreDiags := block.Body.Content(checkRuleBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if attr, exists := content.Attributes["condition"]; exists {
+		cr.Condition = attr.Expr
+	}
+	if attr, exists := content.Attributes["error_message"]; exists {
+		cr.ErrorMessage = attr.Expr
+	}
+
+	return cr, diags
+}
@@ -0,0 +1,66 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hclparse"
+)
+
+func TestDecodeCheckRuleBlock(t *testing.T) {
+	parser := hclparse.NewParser()
+	file, diags := parser.ParseHCL([]byte(`
+precondition {
+  condition     = var.ok
+  error_message = "it is not ok"
+}
+`), "test.tf")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	content, diags := file.Body.Content(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "precondition"}},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if len(content.Blocks) != 1 {
+		t.Fatalf("expected 1 precondition block, got %d", len(content.Blocks))
+	}
+
+	cr, diags := decodeCheckRuleBlock(content.Blocks[0])
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if cr.Condition == nil {
+		t.Error("Condition was not decoded")
+	}
+	if cr.ErrorMessage == nil {
+		t.Error("ErrorMessage was not decoded")
+	}
+}
+
+func TestDecodeCheckRuleBlock_missingAttributes(t *testing.T) {
+	parser := hclparse.NewParser()
+	file, diags := parser.ParseHCL([]byte(`
+postcondition {
+  condition = var.ok
+}
+`), "test.tf")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	content, diags := file.Body.Content(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "postcondition"}},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	_, diags = decodeCheckRuleBlock(content.Blocks[0])
+	if !diags.HasErrors() {
+		t.Fatal("expected an error for a missing error_message attribute")
+	}
+}
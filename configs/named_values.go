@@ -0,0 +1,103 @@
+package configs
+
+import (
+	"github.com/hashicorp/hcl2/gohcl"
+	"github.com/hashicorp/hcl2/hcl"
+)
+
+// Output represents an "output" block in a module or file.
+type Output struct {
+	Name        string
+	Description string
+	Expr        hcl.Expression
+	DependsOn   []hcl.Traversal
+	Sensitive   bool
+
+	// Ephemeral, if true, marks this as a write-only output: its value is
+	// made available to callers of this module for the duration of the
+	// current run but is never written to state nor encoded into the
+	// persisted plan.
+	Ephemeral bool
+
+	// Preconditions and Postconditions hold the precondition and
+	// postcondition check blocks respectively, evaluated during plan
+	// (preconditions, and postconditions when the value is already known)
+	// and re-evaluated against the final value during apply.
+	Preconditions  []*CheckRule
+	Postconditions []*CheckRule
+
+	DescriptionSet bool
+	SensitiveSet   bool
+
+	DeclRange hcl.Range
+}
+
+func decodeOutputBlock(block *hcl.Block) (*Output, hcl.Diagnostics) {
+	o := &Output{
+		Name:      block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+
+	content, diags := block.Body.Content(outputBlockSchema)
+
+	if attr, exists := content.Attributes["value"]; exists {
+		o.Expr = attr.Expr
+	}
+
+	if attr, exists := content.Attributes["description"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &o.Description)
+		diags = append(diags, valDiags...)
+		o.DescriptionSet = true
+	}
+
+	if attr, exists := content.Attributes["sensitive"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &o.Sensitive)
+		diags = append(diags, valDiags...)
+		o.SensitiveSet = true
+	}
+
+	if attr, exists := content.Attributes["ephemeral"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &o.Ephemeral)
+		diags = append(diags, valDiags...)
+	}
+
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "precondition":
+			cr, moreDiags := decodeCheckRuleBlock(block)
+			diags = append(diags, moreDiags...)
+			o.Preconditions = append(o.Preconditions, cr)
+		case "postcondition":
+			cr, moreDiags := decodeCheckRuleBlock(block)
+			diags = append(diags, moreDiags...)
+			o.Postconditions = append(o.Postconditions, cr)
+		}
+	}
+
+	return o, diags
+}
+
+var outputBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name:     "value",
+			Required: true,
+		},
+		{
+			Name: "description",
+		},
+		{
+			Name: "sensitive",
+		},
+		{
+			Name: "ephemeral",
+		},
+		{
+			Name: "depends_on",
+		},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "precondition"},
+		{Type: "postcondition"},
+	},
+}
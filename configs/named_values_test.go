@@ -0,0 +1,80 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hclparse"
+)
+
+func decodeTestOutputBlock(t *testing.T, src string) (*Output, hcl.Diagnostics) {
+	t.Helper()
+
+	parser := hclparse.NewParser()
+	file, diags := parser.ParseHCL([]byte(src), "test.tf")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	content, diags := file.Body.Content(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "output", LabelNames: []string{"name"}}},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if len(content.Blocks) != 1 {
+		t.Fatalf("expected 1 output block, got %d", len(content.Blocks))
+	}
+
+	return decodeOutputBlock(content.Blocks[0])
+}
+
+func TestDecodeOutputBlock_conditions(t *testing.T) {
+	got, diags := decodeTestOutputBlock(t, `
+output "greeting" {
+  value = "hello"
+
+  precondition {
+    condition     = var.ok
+    error_message = "precondition failed"
+  }
+
+  postcondition {
+    condition     = self.value != ""
+    error_message = "postcondition failed"
+  }
+}
+`)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	if got.Name != "greeting" {
+		t.Errorf("wrong name %q", got.Name)
+	}
+	if len(got.Preconditions) != 1 {
+		t.Errorf("got %d preconditions, want 1", len(got.Preconditions))
+	}
+	if len(got.Postconditions) != 1 {
+		t.Errorf("got %d postconditions, want 1", len(got.Postconditions))
+	}
+	if got.Ephemeral {
+		t.Error("Ephemeral should default to false")
+	}
+}
+
+func TestDecodeOutputBlock_ephemeral(t *testing.T) {
+	got, diags := decodeTestOutputBlock(t, `
+output "token" {
+  value     = "shh"
+  ephemeral = true
+}
+`)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	if !got.Ephemeral {
+		t.Error("expected Ephemeral to be true")
+	}
+}
@@ -0,0 +1,181 @@
+package plans
+
+import (
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/hashicorp/terraform/addrs"
+)
+
+// Action describes the type of action planned for an instance of a resource
+// or an output value.
+type Action rune
+
+const (
+	NoOp   Action = 0
+	Create Action = '+'
+	Read   Action = '←'
+	Update Action = '~'
+	Delete Action = '-'
+)
+
+// Change describes a single change with a given action, in terms of the
+// cty.Value representations of its "before" and "after" states.
+type Change struct {
+	Action Action
+	Before cty.Value
+	After  cty.Value
+}
+
+// Changes describes a set of changes planned by Terraform, to be applied
+// together during an apply walk.
+type Changes struct {
+	Outputs []*OutputChangeSrc
+}
+
+// NewChanges returns a valid, empty Changes object ready to be mutated via
+// its Append methods.
+func NewChanges() *Changes {
+	return &Changes{}
+}
+
+// AppendOutputChange records a planned change for a particular output
+// value.
+func (c *Changes) AppendOutputChange(change *OutputChangeSrc) {
+	c.Outputs = append(c.Outputs, change)
+}
+
+// GetOutputChange returns the planned change for the output value with the
+// given address, or nil if no such change is recorded.
+func (c *Changes) GetOutputChange(addr addrs.AbsOutputValue) *OutputChangeSrc {
+	for _, oc := range c.Outputs {
+		if oc.Addr.Equal(addr) {
+			return oc
+		}
+	}
+	return nil
+}
+
+// OutputChange describes a change to an output value.
+type OutputChange struct {
+	Addr addrs.AbsOutputValue
+
+	Change
+
+	// Sensitive, if true, indicates that either the old or new value in
+	// Change is sensitive and so should not be displayed in UI output.
+	Sensitive bool
+
+	// DeferredPostconditions indicates that this output's postconditions
+	// could not be checked during plan because its value was not yet
+	// known, and so must be re-checked once the final value is learned
+	// during apply.
+	DeferredPostconditions bool
+
+	// Ephemeral indicates that this is a write-only output whose value
+	// must never be persisted. Encode deliberately omits Before/After
+	// for an ephemeral change, recording only that the action happened.
+	Ephemeral bool
+}
+
+// Encode produces a variant of the receiver that has its change values
+// serialized so that it can be written to a plan file.
+//
+// For an ephemeral output, the Before/After values are never serialized:
+// only the planned action is recorded, so that the plan file never carries
+// the ephemeral value at rest.
+func (oc *OutputChange) Encode() (*OutputChangeSrc, error) {
+	if oc.Ephemeral {
+		return &OutputChangeSrc{
+			Addr: oc.Addr,
+			ChangeSrc: ChangeSrc{
+				Action: oc.Action,
+			},
+			Sensitive:              oc.Sensitive,
+			DeferredPostconditions: oc.DeferredPostconditions,
+			Ephemeral:              true,
+		}, nil
+	}
+
+	before, err := ctyjson.Marshal(oc.Before, cty.DynamicPseudoType)
+	if err != nil {
+		return nil, err
+	}
+	after, err := ctyjson.Marshal(oc.After, cty.DynamicPseudoType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutputChangeSrc{
+		Addr: oc.Addr,
+		ChangeSrc: ChangeSrc{
+			Action: oc.Action,
+			Before: before,
+			After:  after,
+		},
+		Sensitive:              oc.Sensitive,
+		DeferredPostconditions: oc.DeferredPostconditions,
+	}, nil
+}
+
+// ChangeSrc is a variant of Change that stores the before/after values as
+// serialized JSON, suitable for writing to a plan file.
+type ChangeSrc struct {
+	Action Action
+	Before []byte
+	After  []byte
+}
+
+// OutputChangeSrc is a variant of OutputChange that stores its change
+// values in a serialized form ready to be written to a plan file.
+type OutputChangeSrc struct {
+	Addr addrs.AbsOutputValue
+
+	ChangeSrc
+
+	Sensitive              bool
+	DeferredPostconditions bool
+	Ephemeral              bool
+}
+
+// Decode produces a variant of the receiver that has its change values
+// deserialized from the plan file's serialized form.
+//
+// An ephemeral change never had a Before/After recorded in the first
+// place, so its decoded Change carries only the action, with both values
+// set to an unknown placeholder.
+func (ocs *OutputChangeSrc) Decode() (*OutputChange, error) {
+	if ocs.Ephemeral {
+		return &OutputChange{
+			Addr: ocs.Addr,
+			Change: Change{
+				Action: ocs.Action,
+				Before: cty.DynamicVal,
+				After:  cty.DynamicVal,
+			},
+			Sensitive:              ocs.Sensitive,
+			DeferredPostconditions: ocs.DeferredPostconditions,
+			Ephemeral:              true,
+		}, nil
+	}
+
+	before, err := ctyjson.Unmarshal(ocs.Before, cty.DynamicPseudoType)
+	if err != nil {
+		return nil, err
+	}
+	after, err := ctyjson.Unmarshal(ocs.After, cty.DynamicPseudoType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutputChange{
+		Addr: ocs.Addr,
+		Change: Change{
+			Action: ocs.Action,
+			Before: before,
+			After:  after,
+		},
+		Sensitive:              ocs.Sensitive,
+		DeferredPostconditions: ocs.DeferredPostconditions,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package plans
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/addrs"
+)
+
+func testOutputAddr(name string) addrs.AbsOutputValue {
+	return addrs.OutputValue{Name: name}.Absolute(addrs.RootModuleInstance)
+}
+
+func TestOutputChangeEncodeDecode(t *testing.T) {
+	change := &OutputChange{
+		Addr: testOutputAddr("greeting"),
+		Change: Change{
+			Action: Update,
+			Before: cty.StringVal("old"),
+			After:  cty.StringVal("new"),
+		},
+		Sensitive: true,
+	}
+
+	src, err := change.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(src.Before) == 0 || len(src.After) == 0 {
+		t.Fatal("expected Before/After to be populated for a non-ephemeral change")
+	}
+	if src.Ephemeral {
+		t.Fatal("non-ephemeral change was encoded as ephemeral")
+	}
+
+	got, err := src.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.After.RawEquals(cty.StringVal("new")) {
+		t.Errorf("wrong decoded After value: %#v", got.After)
+	}
+	if !got.Sensitive {
+		t.Error("Sensitive flag was not preserved")
+	}
+}
+
+func TestOutputChangeEncodeDecode_ephemeral(t *testing.T) {
+	change := &OutputChange{
+		Addr: testOutputAddr("token"),
+		Change: Change{
+			Action: Create,
+			Before: cty.NullVal(cty.DynamicPseudoType),
+			After:  cty.StringVal("super-secret"),
+		},
+		Ephemeral: true,
+	}
+
+	src, err := change.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.Before != nil || src.After != nil {
+		t.Fatal("expected an ephemeral change to encode without Before/After")
+	}
+	if !src.Ephemeral {
+		t.Fatal("expected the encoded change to be marked ephemeral")
+	}
+
+	got, err := src.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Action != Create {
+		t.Errorf("wrong decoded action: %s", string(got.Action))
+	}
+	if got.After.RawEquals(cty.StringVal("super-secret")) {
+		t.Fatal("decoded ephemeral change must not reconstruct the original secret value")
+	}
+}
+
+func TestChangesAppendAndGetOutputChange(t *testing.T) {
+	changes := NewChanges()
+	addr := testOutputAddr("greeting")
+
+	if got := changes.GetOutputChange(addr); got != nil {
+		t.Fatalf("expected no change recorded yet, got %#v", got)
+	}
+
+	src := &OutputChangeSrc{Addr: addr, ChangeSrc: ChangeSrc{Action: NoOp}}
+	changes.AppendOutputChange(src)
+
+	got := changes.GetOutputChange(addr)
+	if got == nil {
+		t.Fatal("expected to find the appended change")
+	}
+	if got.Action != NoOp {
+		t.Errorf("wrong action %s", string(got.Action))
+	}
+}
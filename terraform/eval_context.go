@@ -0,0 +1,49 @@
+package terraform
+
+import (
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/tfdiags"
+)
+
+// EvalContext is the interface that is given to each EvalNode implementation
+// to access orchestration state and perform expression evaluation during a
+// graph walk.
+type EvalContext interface {
+	// Path returns the path of the module instance this context is
+	// currently operating within.
+	Path() addrs.ModuleInstance
+
+	// Changes returns the changeset for the current walk, or nil if this
+	// walk does not produce a changeset (such as a plain refresh).
+	Changes() *plans.Changes
+
+	// State returns the mutable state for the current walk.
+	State() *states.SyncState
+
+	// EvaluateExpr evaluates the given HCL expression in the receiver's
+	// current evaluation scope, converting the result to wantType if it
+	// is not cty.NilType. The self argument, if non-nil, makes a "self"
+	// variable available to the expression, referring to the given
+	// referenceable address.
+	EvaluateExpr(expr hcl.Expression, wantType cty.Type, self addrs.Referenceable) (cty.Value, tfdiags.Diagnostics)
+
+	// SetEphemeralOutputValue records the final value of an ephemeral
+	// output so that it's available to callers of this module for the
+	// remainder of the current run. Ephemeral output values are never
+	// recorded in state or in the persisted plan, so this in-memory
+	// registry is the only place such a value exists once this EvalNode
+	// has returned.
+	SetEphemeralOutputValue(addr addrs.AbsOutputValue, val cty.Value)
+
+	// GetEphemeralOutputValue returns the value most recently recorded by
+	// SetEphemeralOutputValue for addr, if any. Expression evaluation
+	// should consult this as a fallback when resolving a module output
+	// reference whose state entry is absent, which is always the case
+	// for an ephemeral output.
+	GetEphemeralOutputValue(addr addrs.AbsOutputValue) (val cty.Value, ok bool)
+}
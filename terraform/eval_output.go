@@ -65,12 +65,33 @@ func (n *EvalPlanOutput) Eval(ctx EvalContext) (interface{}, error) {
 			Sensitive: sensitive,
 		}
 	default:
+		moreDiags := evalOutputConditions(ctx, n.Config.Preconditions, "Precondition failed")
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			return nil, diags.Err()
+		}
+
 		after, moreDiags := ctx.EvaluateExpr(n.Config.Expr, cty.DynamicPseudoType, nil)
 		diags = diags.Append(moreDiags)
 		if moreDiags.HasErrors() {
 			return nil, diags.Err()
 		}
 
+		deferredPostconditions := false
+		if after.IsWhollyKnown() {
+			moreDiags := evalOutputConditions(ctx, n.Config.Postconditions, "Postcondition failed")
+			diags = diags.Append(moreDiags)
+			if moreDiags.HasErrors() {
+				return nil, diags.Err()
+			}
+		} else if len(n.Config.Postconditions) > 0 {
+			// We can't check a postcondition against a value we don't know
+			// yet, so we'll defer the check until apply, once the real
+			// value has been learned.
+			log.Printf("[TRACE] EvalPlanOutput: %s value not yet known, so postconditions will be deferred until apply", addr)
+			deferredPostconditions = true
+		}
+
 		eqV := after.Equals(before)
 		eq := eqV.IsKnown() && eqV.True()
 		var action plans.Action
@@ -90,7 +111,9 @@ func (n *EvalPlanOutput) Eval(ctx EvalContext) (interface{}, error) {
 				Before: before,
 				After:  after,
 			},
-			Sensitive: sensitive,
+			Sensitive:              sensitive,
+			Ephemeral:              n.Config.Ephemeral,
+			DeferredPostconditions: deferredPostconditions,
 		}
 	}
 
@@ -101,6 +124,19 @@ func (n *EvalPlanOutput) Eval(ctx EvalContext) (interface{}, error) {
 	log.Printf("[TRACE] EvalPlanOutput: Recording %s change for %s", changeSrc.Action, addr)
 	changes.AppendOutputChange(changeSrc)
 
+	if n.Config != nil && n.Config.Ephemeral {
+		// Ephemeral outputs are never written to state, nor are their
+		// values retained in the plan file: they exist only in memory for
+		// the remainder of this run so that a calling module can make use
+		// of them without risking leaking a short-lived credential or
+		// token into a persisted artifact. If this output previously had
+		// a persisted value (e.g. it's only just been marked ephemeral),
+		// we must purge that old value rather than leave it behind.
+		state.RemoveOutputValue(addr)
+		ctx.SetEphemeralOutputValue(addr, cty.UnknownAsNull(change.After))
+		return nil, diags.ErrWithWarnings()
+	}
+
 	// We'll also record the planned value in the state for consistency,
 	// but expression evaluation during the plan walk should always prefer
 	// to use the value from the changeset because the state can't represent
@@ -113,8 +149,9 @@ func (n *EvalPlanOutput) Eval(ctx EvalContext) (interface{}, error) {
 // EvalApplyOutput is an EvalNode implementation that handles a
 // previously-planned change to an output value.
 type EvalApplyOutput struct {
-	Addr addrs.OutputValue
-	Expr hcl.Expression
+	Addr   addrs.OutputValue
+	Config *configs.Output
+	Expr   hcl.Expression
 }
 
 // Eval implements EvalNode
@@ -148,8 +185,18 @@ func (n *EvalApplyOutput) Eval(ctx EvalContext) (interface{}, error) {
 
 	switch change.Action {
 	case plans.Delete:
-		state.RemoveOutputValue(addr)
+		if !change.Ephemeral {
+			state.RemoveOutputValue(addr)
+		}
 	default:
+		if n.Config != nil {
+			moreDiags := evalOutputConditions(ctx, n.Config.Preconditions, "Precondition failed")
+			diags = diags.Append(moreDiags)
+			if moreDiags.HasErrors() {
+				return nil, diags.Err()
+			}
+		}
+
 		// The "after" value in our planned change might be incomplete if
 		// it was constructed from unknown values during planning, so we
 		// need to re-evaluate it here to incorporate new values we've
@@ -215,13 +262,34 @@ func (n *EvalApplyOutput) Eval(ctx EvalContext) (interface{}, error) {
 			// resources.
 		}
 
-		// If we had an unknown value during planning then we would've planned
-		// an update, but that unknown can turn out to be null, so we'll
-		// handle that as a special case here.
-		if val.IsNull() {
+		if n.Config != nil {
+			// Postconditions are checked again against the final value,
+			// whether or not they were deferred during plan because the
+			// planned value was still unknown.
+			moreDiags := evalOutputConditions(ctx, n.Config.Postconditions, "Postcondition failed")
+			diags = diags.Append(moreDiags)
+			if moreDiags.HasErrors() {
+				return nil, diags.Err()
+			}
+		}
+
+		switch {
+		case change.Ephemeral:
+			// Ephemeral outputs are made available to the caller of this
+			// module only for the remainder of this run; they're never
+			// written to state. If this output previously had a persisted
+			// value, purge it so a transition to ephemeral doesn't leave a
+			// stale secret behind in state forever.
+			log.Printf("[TRACE] EvalApplyOutput: %s is ephemeral, removing any prior state entry", addr)
+			state.RemoveOutputValue(addr)
+			ctx.SetEphemeralOutputValue(addr, val)
+		case val.IsNull():
+			// If we had an unknown value during planning then we would've
+			// planned an update, but that unknown can turn out to be null,
+			// so we'll handle that as a special case here.
 			log.Printf("[TRACE] EvalApplyOutput: Removing %s from state (it is now null)", addr)
 			state.RemoveOutputValue(addr)
-		} else {
+		default:
 			log.Printf("[TRACE] EvalApplyOutput: Saving new value for %s in state", addr)
 			state.SetOutputValue(addr, val, change.Sensitive)
 		}
@@ -238,6 +306,7 @@ func (n *EvalApplyOutput) Eval(ctx EvalContext) (interface{}, error) {
 // walks must instead use EvalPlanOutput and EvalApplyOutput respectively.
 type EvalRefreshOutput struct {
 	Addr      addrs.OutputValue
+	Config    *configs.Output
 	Sensitive bool
 	Expr      hcl.Expression
 }
@@ -246,9 +315,19 @@ type EvalRefreshOutput struct {
 func (n *EvalRefreshOutput) Eval(ctx EvalContext) (interface{}, error) {
 	addr := n.Addr.Absolute(ctx.Path())
 
+	var diags tfdiags.Diagnostics
+	if n.Config != nil {
+		moreDiags := evalOutputConditions(ctx, n.Config.Preconditions, "Precondition failed")
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			return nil, diags.Err()
+		}
+	}
+
 	// This has to run before we have a state lock, since evaluation also
 	// reads the state
-	val, diags := ctx.EvaluateExpr(n.Expr, cty.DynamicPseudoType, nil)
+	val, moreDiags := ctx.EvaluateExpr(n.Expr, cty.DynamicPseudoType, nil)
+	diags = diags.Append(moreDiags)
 	// We'll handle errors below, after we have loaded the module.
 
 	state := ctx.State()
@@ -261,6 +340,14 @@ func (n *EvalRefreshOutput) Eval(ctx EvalContext) (interface{}, error) {
 		return nil, diags.Err()
 	}
 
+	if n.Config != nil && val.IsWhollyKnown() {
+		moreDiags := evalOutputConditions(ctx, n.Config.Postconditions, "Postcondition failed")
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			return nil, diags.Err()
+		}
+	}
+
 	if !val.IsWhollyKnown() {
 		// Output values should produce unknown values only during the plan
 		// walk, which we deal with in EvalPlanOutput instead.
@@ -276,13 +363,81 @@ func (n *EvalRefreshOutput) Eval(ctx EvalContext) (interface{}, error) {
 		return nil, diags.Err()
 	}
 
-	if val.IsNull() {
+	switch {
+	case n.Config != nil && n.Config.Ephemeral:
+		// Ephemeral outputs are never persisted to state. If a prior run
+		// saved a value for this output before it was marked ephemeral,
+		// purge it now rather than leaving a stale secret in state.
+		log.Printf("[TRACE] EvalRefreshOutput: %s is ephemeral, removing any prior state entry", addr)
+		state.RemoveOutputValue(addr)
+	case val.IsNull():
 		log.Printf("[TRACE] EvalRefreshOutput: Removing %s from state (it is now null)", addr)
 		state.RemoveOutputValue(addr)
-	} else {
+	default:
 		log.Printf("[TRACE] EvalRefreshOutput: Saving value for %s in state", addr)
 		state.SetOutputValue(addr, val, n.Sensitive)
 	}
 
 	return nil, diags.ErrWithWarnings()
 }
+
+// evalOutputConditions evaluates a set of precondition or postcondition
+// check rules attached to an output block, producing an error diagnostic
+// using the given summary for each one whose condition is false.
+//
+// This is shared between EvalPlanOutput, EvalApplyOutput, and
+// EvalRefreshOutput so that preconditions and postconditions behave
+// consistently regardless of which walk is evaluating the output.
+func evalOutputConditions(ctx EvalContext, rules []*configs.CheckRule, diagSummary string) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	for _, rule := range rules {
+		const errInvalidCondition = "Invalid output condition"
+
+		result, moreDiags := ctx.EvaluateExpr(rule.Condition, cty.Bool, nil)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  errInvalidCondition,
+				Detail:   fmt.Sprintf("Invalid condition expression: %s.", moreDiags.Err()),
+				Subject:  rule.Condition.Range().Ptr(),
+			})
+			continue
+		}
+		if !result.IsKnown() {
+			// We should not normally get here for an output precondition,
+			// since it's not expected to depend on values that aren't
+			// known until apply, but we'll tolerate it just in case and
+			// let the postcondition (if any) be re-checked on a later walk.
+			continue
+		}
+		if result.IsNull() {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  errInvalidCondition,
+				Detail:   "Condition expression must return either true or false, not null.",
+				Subject:  rule.Condition.Range().Ptr(),
+			})
+			continue
+		}
+		if result.True() {
+			continue
+		}
+
+		errorMessage, moreDiags := ctx.EvaluateExpr(rule.ErrorMessage, cty.String, nil)
+		diags = diags.Append(moreDiags)
+		detail := "Failed to evaluate condition error message."
+		if !moreDiags.HasErrors() && errorMessage.IsKnown() && !errorMessage.IsNull() {
+			detail = errorMessage.AsString()
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  diagSummary,
+			Detail:   detail,
+			Subject:  rule.DeclRange.Ptr(),
+		})
+	}
+
+	return diags
+}
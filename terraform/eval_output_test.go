@@ -0,0 +1,74 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/configs"
+	"github.com/hashicorp/terraform/tfdiags"
+)
+
+// fakeCheckEvalContext is a minimal EvalContext stub for exercising
+// evalOutputConditions in isolation: it answers a boolean expression with
+// result and a string expression with "condition failed", which is all
+// that test needs from expression evaluation.
+type fakeCheckEvalContext struct {
+	EvalContext
+	result cty.Value
+}
+
+func (c *fakeCheckEvalContext) EvaluateExpr(expr hcl.Expression, wantType cty.Type, self addrs.Referenceable) (cty.Value, tfdiags.Diagnostics) {
+	if wantType == cty.String {
+		return cty.StringVal("condition failed"), nil
+	}
+	return c.result, nil
+}
+
+func mustTestExpr(t *testing.T, src string) hcl.Expression {
+	t.Helper()
+	expr, diags := hclsyntax.ParseExpression([]byte(src), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	return expr
+}
+
+func TestEvalOutputConditions(t *testing.T) {
+	tests := map[string]struct {
+		result  cty.Value
+		wantErr bool
+	}{
+		"condition is true":             {cty.True, false},
+		"condition is false":            {cty.False, true},
+		"condition is unknown":          {cty.UnknownVal(cty.Bool), false},
+		"condition is null is an error": {cty.NullVal(cty.Bool), true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule := &configs.CheckRule{
+				Condition:    mustTestExpr(t, "true"),
+				ErrorMessage: mustTestExpr(t, `"unused"`),
+				DeclRange:    hcl.Range{Filename: "test.tf"},
+			}
+			ctx := &fakeCheckEvalContext{result: test.result}
+
+			diags := evalOutputConditions(ctx, []*configs.CheckRule{rule}, "Precondition failed")
+			if got := diags.HasErrors(); got != test.wantErr {
+				t.Fatalf("HasErrors() = %v, want %v (diags: %s)", got, test.wantErr, diags.Err())
+			}
+		})
+	}
+}
+
+func TestEvalOutputConditions_noRules(t *testing.T) {
+	ctx := &fakeCheckEvalContext{}
+	diags := evalOutputConditions(ctx, nil, "Precondition failed")
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors with no check rules: %s", diags.Err())
+	}
+}
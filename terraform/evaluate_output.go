@@ -0,0 +1,26 @@
+package terraform
+
+import (
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/addrs"
+)
+
+// outputValueForExpr resolves the value of addr for use when evaluating a
+// reference to a module output value, such as "module.child.some_output".
+//
+// It prefers the value recorded in state, but an ephemeral output is never
+// written to state, so when no state entry is found it falls back to the
+// in-memory registry populated by EvalPlanOutput/EvalApplyOutput via
+// SetEphemeralOutputValue. The second return value is false if the output
+// has no known value by either means, which is the normal case for an
+// output that simply doesn't exist.
+func outputValueForExpr(ctx EvalContext, addr addrs.AbsOutputValue) (cty.Value, bool) {
+	if state := ctx.State(); state != nil {
+		if os := state.OutputValue(addr); os != nil {
+			return os.Value, true
+		}
+	}
+
+	return ctx.GetEphemeralOutputValue(addr)
+}